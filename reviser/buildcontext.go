@@ -0,0 +1,72 @@
+package reviser
+
+import (
+	"go/ast"
+	"go/build"
+
+	"github.com/walteh/goimports-reviser/v3/pkg/astutil"
+)
+
+// WithBuildContexts makes unused-import removal evaluate usage under every
+// given build context (e.g. distinct GOOS/GOARCH combinations) instead of
+// only the host toolchain's default context, mirroring how cmd/api scans a
+// fixed GOOS/GOARCH/CgoEnabled matrix. An import is removed only if it is
+// unused in all of them, so imports only referenced from e.g. a
+// `//go:build windows` file are kept when linux is also in scope. Without
+// this option, SourceFile keeps its previous single-context behavior.
+// Only each context's GOOS and GOARCH are used; its other fields, such as
+// CgoEnabled and BuildTags, are ignored.
+func WithBuildContexts(contexts []build.Context) SourceFileOption {
+	return func(f *SourceFile) error {
+		f.buildContexts = contexts
+		return nil
+	}
+}
+
+// buildContextsOrDefault returns f.buildContexts and whether it fell back to
+// the host toolchain's default context, so callers that never called
+// WithBuildContexts keep evaluating exactly one context the same way as
+// before WithBuildContexts existed.
+func (f *SourceFile) buildContextsOrDefault() (contexts []build.Context, isDefault bool) {
+	if len(f.buildContexts) == 0 {
+		return []build.Context{build.Default}, true
+	}
+
+	return f.buildContexts, false
+}
+
+// buildTagForContext returns the build tag astutil.LoadPackageDependencies
+// should use to select sibling package files under ctx.
+//
+// When isDefault is true (no WithBuildContexts given), it returns exactly
+// astutil.ParseBuildTag(file), matching the tag SourceFile always used
+// before WithBuildContexts existed. Otherwise it returns ctx's own
+// GOOS/GOARCH alone: ANDing it with the file's own parsed tag would produce
+// a self-contradictory constraint for any file that already carries a
+// `//go:build` line (e.g. a `windows`-tagged file combined with a `linux`
+// context would require both), so each explicit context is evaluated on its
+// own terms instead.
+func buildTagForContext(file *ast.File, ctx build.Context, isDefault bool) string {
+	if isDefault {
+		return astutil.ParseBuildTag(file)
+	}
+
+	ctxTag := ctx.GOOS
+	if ctx.GOARCH != "" {
+		ctxTag += "," + ctx.GOARCH
+	}
+
+	return ctxTag
+}
+
+// usedInAnyContext reports whether importPath is used under at least one of
+// the given per-context dependency maps.
+func usedInAnyContext(file *ast.File, packageImportsPerContext []map[string]string, importPath string) bool {
+	for _, packageImports := range packageImportsPerContext {
+		if astutil.UsesImport(file, packageImports, importPath) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,50 @@
+package reviser
+
+import (
+	"go/build"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/walteh/goimports-reviser/v3/pkg/astutil"
+)
+
+const taggedFileSource = `//go:build windows
+
+package example
+
+import "fmt"
+
+func main() {
+	fmt.Println("hi")
+}
+`
+
+func TestBuildTagForContext_DefaultMatchesBaseline(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", taggedFileSource, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+
+	want := astutil.ParseBuildTag(file)
+
+	for _, ctx := range []build.Context{build.Default, {GOOS: "linux", GOARCH: "amd64"}} {
+		if got := buildTagForContext(file, ctx, true); got != want {
+			t.Fatalf("buildTagForContext(isDefault=true) = %q, want %q (baseline tag, ctx must be ignored)", got, want)
+		}
+	}
+}
+
+func TestBuildTagForContext_ExplicitContextIgnoresFileTag(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", taggedFileSource, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+
+	got := buildTagForContext(file, build.Context{GOOS: "linux", GOARCH: "amd64"}, false)
+	if got != "linux,amd64" {
+		t.Fatalf("buildTagForContext(isDefault=false) = %q, want %q (must not AND with the file's own windows tag)", got, "linux,amd64")
+	}
+}
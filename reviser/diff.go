@@ -0,0 +1,60 @@
+package reviser
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/pkg/diff"
+)
+
+// WithDiff makes FixDiff additionally compute a unified diff (in the style
+// of `gofmt -d`) between the original and formatted content.
+func WithDiff() SourceFileOption {
+	return func(f *SourceFile) error {
+		f.shouldGenerateDiff = true
+		return nil
+	}
+}
+
+// WithListOnly makes Fix and FixDiff only report whether filePath would
+// change, mirroring `gofmt -l`: no diff is generated and no formatted content
+// is returned (the file is still formatted internally to detect the change).
+// Pairs well with Formatter for CI usage where the content itself isn't needed.
+func WithListOnly() SourceFileOption {
+	return func(f *SourceFile) error {
+		f.shouldListOnly = true
+		return nil
+	}
+}
+
+// FixDiff behaves like Fix but, when WithDiff is set, also returns a unified
+// diff between the original and formatted content labelled with filePath,
+// matching `gofmt -d` conventions.
+func (f *SourceFile) FixDiff(options ...SourceFileOption) (formattedContent, originalContent, diffContent []byte, hasChange bool, err error) {
+	formattedContent, originalContent, hasChange, err = f.Fix(options...)
+	if err != nil {
+		return nil, originalContent, nil, false, err
+	}
+
+	if f.shouldGenerateDiff && !f.shouldListOnly && hasChange {
+		diffContent, err = unifiedDiff(f.filePath, originalContent, formattedContent)
+		if err != nil {
+			return formattedContent, originalContent, nil, hasChange, err
+		}
+	}
+
+	return formattedContent, originalContent, diffContent, hasChange, nil
+}
+
+// unifiedDiff renders a `gofmt -d`-style unified diff between original and
+// formatted, labelling the hunks with filePath.
+func unifiedDiff(filePath string, original, formatted []byte) ([]byte, error) {
+	var buf bytes.Buffer
+
+	err := diff.Text(fmt.Sprintf("a/%s", filePath), fmt.Sprintf("b/%s", filePath), string(original), string(formatted), &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
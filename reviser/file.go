@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"go/ast"
+	"go/build"
 	"go/format"
 	"go/parser"
 	"go/printer"
@@ -37,9 +38,14 @@ type SourceFile struct {
 	shouldSkipAutoGenerated        bool
 	shouldSeparateNamedImports     bool
 	hasSeparateSideEffectGroup     bool
+	shouldGenerateDiff             bool
+	shouldListOnly                 bool
+	preserveImportDocs             bool
 	companyPackagePrefixes         []string
 	importsOrders                  ImportsOrders
 	renameImports                  map[string]string
+	stdResolver                    StdResolver
+	buildContexts                  []build.Context
 
 	projectName string
 	filePath    string
@@ -49,8 +55,9 @@ type SourceFile struct {
 // NewSourceFile constructor
 func NewSourceFile(projectName, filePath string) *SourceFile {
 	return &SourceFile{
-		projectName: projectName,
-		filePath:    filePath,
+		projectName:        projectName,
+		filePath:           filePath,
+		preserveImportDocs: true,
 	}
 }
 
@@ -119,7 +126,13 @@ func (f *SourceFile) Fix(options ...SourceFileOption) ([]byte, []byte, bool, err
 		return nil, originalContent, false, err
 	}
 
-	return formattedContent, originalContent, !bytes.Equal(originalContent, formattedContent), nil
+	hasChange := !bytes.Equal(originalContent, formattedContent)
+
+	if f.shouldListOnly {
+		return nil, originalContent, hasChange, nil
+	}
+
+	return formattedContent, originalContent, hasChange, nil
 }
 
 func isFileAutoGenerate(pf *ast.File) bool {
@@ -213,7 +226,7 @@ func (f *SourceFile) groupImports(
 			continue
 		}
 
-		if _, ok := std.StdPackages[pkgWithoutAlias]; ok {
+		if f.isStdPackage(pkgWithoutAlias) {
 			if f.shouldSeparateNamedImports {
 				if len(values) > 1 {
 					namedStdImports = append(namedStdImports, imprt)
@@ -304,6 +317,18 @@ func (f *SourceFile) groupImports(
 	return result
 }
 
+// isStdPackage reports whether pkgPath is part of the Go standard library,
+// preferring f.stdResolver when one was injected via WithStdResolver and
+// falling back to the embedded, generated pkg/std list otherwise.
+func (f *SourceFile) isStdPackage(pkgPath string) bool {
+	if f.stdResolver != nil {
+		return f.stdResolver.IsStd(pkgPath)
+	}
+
+	_, ok := std.StdPackages[pkgPath]
+	return ok
+}
+
 func skipPackageAlias(pkg string) string {
 	values := strings.Split(pkg, " ")
 	if len(values) > 1 {
@@ -358,7 +383,7 @@ func (f *SourceFile) fixImports(
 
 		imports := f.importsOrders.sortImportsByOrder(groups)
 
-		dd.Specs = rebuildImports(dd.Tok, commentsMetadata, imports)
+		dd.Specs = rebuildImports(dd.Tok, commentsMetadata, imports, f.preserveImportDocs)
 	}
 
 	clearImportDocs(file, importsPositions)
@@ -450,7 +475,7 @@ func removeEmptyImportNode(f *ast.File) {
 	}
 }
 
-func rebuildImports(tok token.Token, commentsMetadata map[string]*commentsMetadata, imports [][]string) []ast.Spec {
+func rebuildImports(tok token.Token, commentsMetadata map[string]*commentsMetadata, imports [][]string, preserveDocs bool) []ast.Spec {
 	var specs []ast.Spec
 
 	for i, group := range imports {
@@ -460,9 +485,15 @@ func rebuildImports(tok token.Token, commentsMetadata map[string]*commentsMetada
 			specs = append(specs, spec)
 		}
 		for _, imprt := range group {
+			value := importWithComment(imprt, commentsMetadata)
+			if preserveDocs {
+				value = importWithDoc(imprt, value, commentsMetadata)
+			}
+
 			spec := &ast.ImportSpec{
-				Path: &ast.BasicLit{Value: importWithComment(imprt, commentsMetadata), Kind: tok},
+				Path: &ast.BasicLit{Value: value, Kind: tok},
 			}
+
 			specs = append(specs, spec)
 		}
 	}
@@ -470,6 +501,31 @@ func rebuildImports(tok token.Token, commentsMetadata map[string]*commentsMetada
 	return specs
 }
 
+// importWithDoc prepends the doc comment group (e.g. "// Deprecated: ...",
+// "// BUG(...)", or a multi-line doc block) originally attached to imprt in
+// front of valueWithComment.
+//
+// clearImportDocs puts the rebuilt *ast.File into go/printer's
+// positional-comment mode, where ImportSpec.Doc is never consulted, so doc
+// comments can't be re-attached as real AST nodes the way importWithComment
+// embeds the trailing comment into the BasicLit value; it folds them into
+// the printed text the same way instead.
+func importWithDoc(imprt, valueWithComment string, commentsMetadata map[string]*commentsMetadata) string {
+	metadata, ok := commentsMetadata[imprt]
+	if !ok || metadata == nil || metadata.Doc == nil {
+		return valueWithComment
+	}
+
+	var doc strings.Builder
+	for _, comment := range metadata.Doc.List {
+		doc.WriteString(comment.Text)
+		doc.WriteString("\n\t")
+	}
+	doc.WriteString(valueWithComment)
+
+	return doc.String()
+}
+
 func clearImportDocs(f *ast.File, importsPositions []*importPosition) {
 	importsComments := make([]*ast.CommentGroup, 0, len(f.Comments))
 
@@ -510,13 +566,19 @@ func (f *SourceFile) parseImports(file *ast.File) (map[string]*commentsMetadata,
 	shouldUseAliasForVersionSuffix := f.shouldUseAliasForVersionSuffix
 
 	var packageImports map[string]string
+	var packageImportsPerContext []map[string]string
 
 	if shouldRemoveUnusedImports || shouldUseAliasForVersionSuffix {
-		var err error
-		packageImports, err = astutil.LoadPackageDependencies(filepath.Dir(f.filePath), astutil.ParseBuildTag(file))
-		if err != nil {
-			return nil, err
+		buildContexts, isDefaultContext := f.buildContextsOrDefault()
+		for _, buildCtx := range buildContexts {
+			deps, err := astutil.LoadPackageDependencies(filepath.Dir(f.filePath), buildTagForContext(file, buildCtx, isDefaultContext))
+			if err != nil {
+				return nil, err
+			}
+			packageImportsPerContext = append(packageImportsPerContext, deps)
 		}
+
+		packageImports = packageImportsPerContext[0]
 	}
 
 	for _, decl := range file.Decls {
@@ -530,8 +592,8 @@ func (f *SourceFile) parseImports(file *ast.File) (map[string]*commentsMetadata,
 		for _, spec := range dd.Specs {
 			importSpec := spec.(*ast.ImportSpec)
 
-			if shouldRemoveUnusedImports && !astutil.UsesImport(
-				file, packageImports, strings.Trim(importSpec.Path.Value, `"`),
+			if shouldRemoveUnusedImports && !usedInAnyContext(
+				file, packageImportsPerContext, strings.Trim(importSpec.Path.Value, `"`),
 			) {
 				continue
 			}
@@ -0,0 +1,168 @@
+package reviser
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"golang.org/x/mod/modfile"
+)
+
+// FileResult is the outcome of running a Formatter over a single file.
+type FileResult struct {
+	FilePath        string
+	FixedContent    []byte
+	OriginalContent []byte
+	Diff            []byte
+	HasChange       bool
+	Err             error
+}
+
+// FormatterOption configures a Formatter.
+type FormatterOption func(*Formatter)
+
+// WithWorkers sets how many files a Formatter processes concurrently.
+// Values <= 0 fall back to runtime.NumCPU().
+func WithWorkers(workers int) FormatterOption {
+	return func(f *Formatter) {
+		f.workers = workers
+	}
+}
+
+// WithFormatterOptions sets the SourceFileOption slice applied to every file
+// a Formatter processes.
+func WithFormatterOptions(options ...SourceFileOption) FormatterOption {
+	return func(f *Formatter) {
+		f.fileOptions = options
+	}
+}
+
+// Formatter revises and formats many files concurrently, sharing the go.mod
+// resolution and standard library detection that SourceFile would otherwise
+// recompute for every single file.
+type Formatter struct {
+	workers     int
+	fileOptions []SourceFileOption
+	stdResolver StdResolver
+
+	mu      sync.RWMutex
+	visited map[string]struct{}
+}
+
+// NewFormatter constructor.
+func NewFormatter(options ...FormatterOption) *Formatter {
+	f := &Formatter{
+		workers:     runtime.NumCPU(),
+		stdResolver: NewDynamicStdResolver(),
+		visited:     make(map[string]struct{}),
+	}
+
+	for _, option := range options {
+		option(f)
+	}
+
+	if f.workers <= 0 {
+		f.workers = runtime.NumCPU()
+	}
+
+	return f
+}
+
+// Format revises and formats every path concurrently, returning one
+// FileResult per path in the order the paths were given.
+func (f *Formatter) Format(ctx context.Context, paths ...string) ([]FileResult, error) {
+	results := make([]FileResult, len(paths))
+
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < f.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				results[idx] = f.formatOne(ctx, paths[idx])
+			}
+		}()
+	}
+
+	for i := range paths {
+		jobs <- i
+	}
+	close(jobs)
+
+	wg.Wait()
+
+	return results, ctx.Err()
+}
+
+func (f *Formatter) formatOne(ctx context.Context, filePath string) FileResult {
+	if ctx.Err() != nil {
+		return FileResult{FilePath: filePath, Err: ctx.Err()}
+	}
+
+	if f.alreadyFormatted(filePath) {
+		return FileResult{FilePath: filePath}
+	}
+
+	sourceFile, err := NewSourceFileAuto(filePath)
+	if err != nil {
+		return FileResult{FilePath: filePath, Err: err}
+	}
+
+	options := append([]SourceFileOption{WithStdResolver(f.stdResolver)}, f.fileOptions...)
+
+	fixedContent, originalContent, diffContent, hasChange, err := sourceFile.FixDiff(options...)
+	if err != nil {
+		return FileResult{FilePath: filePath, OriginalContent: originalContent, Err: err}
+	}
+
+	f.markFormatted(filePath)
+
+	return FileResult{
+		FilePath:        filePath,
+		FixedContent:    fixedContent,
+		OriginalContent: originalContent,
+		Diff:            diffContent,
+		HasChange:       hasChange,
+	}
+}
+
+func (f *Formatter) alreadyFormatted(filePath string) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	_, ok := f.visited[filePath]
+	return ok
+}
+
+func (f *Formatter) markFormatted(filePath string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.visited[filePath] = struct{}{}
+}
+
+// findModuleName walks up from dir looking for a go.mod file and returns its
+// module directive.
+func findModuleName(dir string) (string, error) {
+	for {
+		data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+		if err == nil {
+			return modfile.ModulePath(data), nil
+		}
+
+		if !os.IsNotExist(err) {
+			return "", err
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("reviser: no go.mod found above %s", dir)
+		}
+		dir = parent
+	}
+}
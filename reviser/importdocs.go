@@ -0,0 +1,11 @@
+package reviser
+
+// WithPreserveImportDocs controls whether doc comments attached to
+// individual imports (e.g. "// Deprecated: ...", "// BUG(...)", or a
+// multi-line doc block) survive re-sorting. Defaults to true.
+func WithPreserveImportDocs(preserve bool) SourceFileOption {
+	return func(f *SourceFile) error {
+		f.preserveImportDocs = preserve
+		return nil
+	}
+}
@@ -0,0 +1,46 @@
+package reviser
+
+import (
+	"strings"
+	"testing"
+)
+
+const docCommentSource = `package example
+
+import (
+	"fmt"
+
+	// Deprecated: use context instead.
+	"errors"
+)
+
+func main() {
+	fmt.Println(errors.New("boom"))
+}
+`
+
+func TestFix_PreservesImportDocComments(t *testing.T) {
+	sourceFile := NewSourceFile("example", "example.go")
+
+	formatted, _, _, err := sourceFile.Fix(WithReader(strings.NewReader(docCommentSource)))
+	if err != nil {
+		t.Fatalf("Fix() error = %v", err)
+	}
+
+	if !strings.Contains(string(formatted), "// Deprecated: use context instead.") {
+		t.Fatalf("expected formatted output to retain the doc comment, got:\n%s", formatted)
+	}
+}
+
+func TestFix_DropsImportDocCommentsWhenDisabled(t *testing.T) {
+	sourceFile := NewSourceFile("example", "example.go")
+
+	formatted, _, _, err := sourceFile.Fix(WithReader(strings.NewReader(docCommentSource)), WithPreserveImportDocs(false))
+	if err != nil {
+		t.Fatalf("Fix() error = %v", err)
+	}
+
+	if strings.Contains(string(formatted), "Deprecated") {
+		t.Fatalf("expected formatted output to drop the doc comment, got:\n%s", formatted)
+	}
+}
@@ -0,0 +1,64 @@
+package reviser
+
+import (
+	"path/filepath"
+	"sync"
+)
+
+// NewSourceFileAuto constructs a SourceFile whose projectName is discovered
+// by walking up from filePath's directory to the nearest go.mod, instead of
+// requiring the caller to pre-compute the module path.
+func NewSourceFileAuto(filePath string) (*SourceFile, error) {
+	projectName, err := defaultProjectNameResolver.resolve(filepath.Dir(filePath))
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSourceFile(projectName, filePath), nil
+}
+
+// WithAutoProjectName overrides projectName by walking up from filePath's
+// directory to the nearest go.mod and using its module directive, rather
+// than the value passed to NewSourceFile.
+func WithAutoProjectName() SourceFileOption {
+	return func(f *SourceFile) error {
+		projectName, err := defaultProjectNameResolver.resolve(filepath.Dir(f.filePath))
+		if err != nil {
+			return err
+		}
+
+		f.projectName = projectName
+		return nil
+	}
+}
+
+// defaultProjectNameResolver is shared by NewSourceFileAuto and
+// WithAutoProjectName so batch runs resolve each directory's module path
+// once; files in a directory not seen before still re-read the go.mod.
+var defaultProjectNameResolver = &projectNameResolver{cache: make(map[string]string)}
+
+// projectNameResolver resolves and caches go.mod module paths per directory.
+type projectNameResolver struct {
+	mu    sync.RWMutex
+	cache map[string]string
+}
+
+func (r *projectNameResolver) resolve(dir string) (string, error) {
+	r.mu.RLock()
+	name, ok := r.cache[dir]
+	r.mu.RUnlock()
+	if ok {
+		return name, nil
+	}
+
+	name, err := findModuleName(dir)
+	if err != nil {
+		return "", err
+	}
+
+	r.mu.Lock()
+	r.cache[dir] = name
+	r.mu.Unlock()
+
+	return name, nil
+}
@@ -0,0 +1,72 @@
+package reviser
+
+import (
+	"errors"
+	"sync"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/walteh/goimports-reviser/v3/pkg/std"
+)
+
+var errNoStdPackages = errors.New("reviser: go/packages returned no std packages")
+
+// StdResolver reports whether pkgPath belongs to the Go standard library.
+type StdResolver interface {
+	IsStd(pkgPath string) bool
+}
+
+// WithStdResolver overrides the resolver SourceFile uses to classify
+// standard library imports. Without it, SourceFile falls back to the
+// embedded, generated pkg/std list.
+func WithStdResolver(resolver StdResolver) SourceFileOption {
+	return func(f *SourceFile) error {
+		f.stdResolver = resolver
+		return nil
+	}
+}
+
+// dynamicStdResolver loads the standard library package set once per
+// resolver via `go list std`, so classification tracks whatever Go toolchain the user
+// is actually running instead of a list pinned to a single Go release.
+type dynamicStdResolver struct {
+	once     sync.Once
+	loadErr  error
+	packages map[string]struct{}
+}
+
+// NewDynamicStdResolver constructs a StdResolver backed by `go list std`,
+// falling back to the embedded static pkg/std list if that fails, e.g. in a
+// sandboxed environment without a Go toolchain on PATH.
+func NewDynamicStdResolver() StdResolver {
+	return &dynamicStdResolver{}
+}
+
+func (r *dynamicStdResolver) IsStd(pkgPath string) bool {
+	r.once.Do(r.load)
+
+	if r.loadErr != nil {
+		_, ok := std.StdPackages[pkgPath]
+		return ok
+	}
+
+	_, ok := r.packages[pkgPath]
+	return ok
+}
+
+func (r *dynamicStdResolver) load() {
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.NeedName}, "std")
+	if err != nil {
+		r.loadErr = err
+		return
+	}
+	if len(pkgs) == 0 {
+		r.loadErr = errNoStdPackages
+		return
+	}
+
+	r.packages = make(map[string]struct{}, len(pkgs))
+	for _, pkg := range pkgs {
+		r.packages[pkg.PkgPath] = struct{}{}
+	}
+}